@@ -0,0 +1,167 @@
+package vincenty
+
+/**
+ * Copyright (c) 2020, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// PointPair is a pair of points whose geodesic distance is computed by
+// InverseBatch, InverseMatrix and InverseStream.
+type PointPair struct {
+	Point1 LatLng
+	Point2 LatLng
+}
+
+// InverseBatch computes the Result for every pair in pairs using
+// DefaultGeodesic, sharding the work across runtime.GOMAXPROCS(0) goroutines.
+// The returned slice is in the same order as pairs. Use
+// DefaultGeodesic.InverseBatch directly to pass a context.Context or a
+// differently configured Geodesic.
+func InverseBatch(pairs []PointPair) []Result {
+	return DefaultGeodesic.InverseBatch(context.Background(), pairs)
+}
+
+// InverseMatrix computes the symmetric geodesic distance matrix between every
+// pair of points using DefaultGeodesic. matrix[i][j] == matrix[j][i] is the
+// distance between points[i] and points[j], and matrix[i][i] is always 0. Use
+// DefaultGeodesic.InverseMatrix directly to pass a context.Context or a
+// differently configured Geodesic.
+func InverseMatrix(points []LatLng) [][]Distance {
+	return DefaultGeodesic.InverseMatrix(context.Background(), points)
+}
+
+// InverseStream reads PointPairs from in, computes their Result using
+// DefaultGeodesic across runtime.GOMAXPROCS(0) goroutines, and writes the
+// results to out, closing out once in is drained. It blocks until in is
+// closed and every in-flight result has been sent. Use
+// DefaultGeodesic.InverseStream directly to pass a context.Context or a
+// differently configured Geodesic.
+func InverseStream(in <-chan PointPair, out chan<- Result) {
+	DefaultGeodesic.InverseStream(context.Background(), in, out)
+}
+
+// InverseBatch computes the Result for every pair in pairs, sharding the work
+// across runtime.GOMAXPROCS(0) goroutines. The returned slice is in the same
+// order as pairs. If ctx is cancelled, goroutines stop early and the
+// corresponding entries are left as the zero Result.
+func (g Geodesic)InverseBatch(ctx context.Context, pairs []PointPair) []Result {
+	results := make([]Result, len(pairs))
+	g.parallelRange(ctx, len(pairs), func(i int) {
+		res, _ := g.Inverse(pairs[i].Point1, pairs[i].Point2)
+		results[i] = res
+	})
+	return results
+}
+
+// InverseMatrix computes the symmetric geodesic distance matrix between every
+// pair of points, sharding the n*(n-1)/2 unique pairs across
+// runtime.GOMAXPROCS(0) goroutines. matrix[i][j] == matrix[j][i] is the
+// distance between points[i] and points[j], and matrix[i][i] is always 0. If
+// ctx is cancelled, goroutines stop early and the remaining cells are left
+// zero.
+func (g Geodesic)InverseMatrix(ctx context.Context, points []LatLng) [][]Distance {
+	n := len(points)
+	matrix := make([][]Distance, n)
+	for i := range matrix {
+		matrix[i] = make([]Distance, n)
+	}
+
+	type cell struct {
+		i, j int
+	}
+	cells := make([]cell, 0, n*(n-1)/2)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			cells = append(cells, cell{i, j})
+		}
+	}
+
+	g.parallelRange(ctx, len(cells), func(k int) {
+		c := cells[k]
+		res, _ := g.Inverse(points[c.i], points[c.j])
+		matrix[c.i][c.j] = res.Distance
+		matrix[c.j][c.i] = res.Distance
+	})
+	return matrix
+}
+
+// InverseStream reads PointPairs from in, computes their Result across
+// runtime.GOMAXPROCS(0) goroutines, and writes the results to out, closing
+// out once in is drained or ctx is cancelled. It blocks until then, so
+// callers typically run it in its own goroutine.
+func (g Geodesic)InverseStream(ctx context.Context, in <-chan PointPair, out chan<- Result) {
+	defer close(out)
+
+	workers := runtime.GOMAXPROCS(0)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case pair, ok := <-in:
+					if !ok {
+						return
+					}
+					res, _ := g.Inverse(pair.Point1, pair.Point2)
+					select {
+					case out <- res:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// parallelRange calls fn(i) for every i in [0, n), sharding the range evenly
+// across runtime.GOMAXPROCS(0) goroutines and waiting for all of them to
+// finish or for ctx to be cancelled.
+func (g Geodesic)parallelRange(ctx context.Context, n int, fn func(i int)) {
+	if n == 0 {
+		return
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start := w * chunk
+		if start >= n {
+			break
+		}
+		end := start + chunk
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					fn(i)
+				}
+			}
+		}(start, end)
+	}
+	wg.Wait()
+}
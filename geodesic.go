@@ -0,0 +1,286 @@
+package vincenty
+
+/**
+ * Copyright (c) 2020, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"errors"
+	"math"
+)
+
+// Ellipsoid describes a reference ellipsoid model of the Earth (or any other
+// body) in terms of its semi-major axis A (in metres) and its flattening F.
+type Ellipsoid struct {
+	A float64
+	F float64
+}
+
+// b returns the ellipsoid's semi-minor axis, b = (1 - f)a
+func (e Ellipsoid)b() float64 {
+	return (1.0 - e.F) * e.A
+}
+
+var (
+	// WGS84 is the World Geodetic System 1984 ellipsoid, used by GPS and by
+	// DefaultGeodesic
+	WGS84 = Ellipsoid{A: 6378137.0, F: 1.0 / 298.257223563}
+
+	// GRS80 is the Geodetic Reference System 1980 ellipsoid
+	GRS80 = Ellipsoid{A: 6378137.0, F: 1.0 / 298.257222101}
+
+	// Airy1830 is the Airy 1830 ellipsoid, historically used for the Ordnance
+	// Survey of Great Britain
+	Airy1830 = Ellipsoid{A: 6377563.396, F: 1.0 / 299.3249646}
+
+	// Clarke1866 is the Clarke 1866 ellipsoid, historically used by NAD27
+	Clarke1866 = Ellipsoid{A: 6378206.4, F: 1.0 / 294.9786982}
+)
+
+// NearAntipodalStrategy controls how Geodesic.Inverse behaves when the two
+// points are close enough to antipodal that Vincenty's formula converges
+// slowly, or not at all, within the configured MaxIterations.
+type NearAntipodalStrategy int
+
+const (
+	// ReturnError reports ErrDidNotConverge and an undefined Distance when
+	// the formula fails to converge. This is the default.
+	ReturnError NearAntipodalStrategy = iota
+
+	// FallBackToGreatCircle falls back to a spherical great-circle
+	// approximation when Vincenty's formula fails to converge.
+	FallBackToGreatCircle
+
+	// UseExtendedVincenty retries a failed solve with a ten-fold increase in
+	// both MaxIterations and ConvergenceThreshold before giving up.
+	UseExtendedVincenty
+
+	// FallBackBisect retries a failed solve with InverseBisect, which stays
+	// convergent for the near-antipodal pairs that defeat Vincenty's formula.
+	FallBackBisect
+)
+
+// ErrDidNotConverge is returned by Geodesic.Inverse when Vincenty's formula
+// fails to converge within MaxIterations and NearAntipodalStrategy is
+// ReturnError (the default) or UseExtendedVincenty also fails to converge.
+var ErrDidNotConverge = errors.New("vincenty: inverse formula failed to converge")
+
+// Geodesic configures the ellipsoid and solver behaviour used by its Inverse
+// and Direct methods. Use NewGeodesic to construct one; the zero value uses
+// an ellipsoid with A = F = 0 and is not usable.
+type Geodesic struct {
+	Ellipsoid Ellipsoid
+	MaxIterations int
+	ConvergenceThreshold float64
+	NearAntipodalStrategy NearAntipodalStrategy
+}
+
+// NewGeodesic returns a Geodesic for the given ellipsoid, with the package's
+// default iteration budget, convergence threshold and near-antipodal
+// strategy (200 iterations, 1e-12, ReturnError).
+func NewGeodesic(ellipsoid Ellipsoid) Geodesic {
+	return Geodesic{
+		Ellipsoid: ellipsoid,
+		MaxIterations: 200,
+		ConvergenceThreshold: 1e-12,
+		NearAntipodalStrategy: ReturnError,
+	}
+}
+
+// DefaultGeodesic is the WGS84 Geodesic used by the package-level Inverse,
+// InverseDetailed and Direct functions.
+var DefaultGeodesic = NewGeodesic(WGS84)
+
+// Inverse calculates the distance, and the initial and final bearings,
+// between two points on the surface of g's ellipsoid using Vincenty's
+// formula (inverse method). Result.Iterations and Result.Converged are
+// always populated for diagnostics.
+//
+// If the formula fails to converge within g.MaxIterations, the behaviour is
+// determined by g.NearAntipodalStrategy: ReturnError (the default) returns
+// ErrDidNotConverge, FallBackToGreatCircle returns a spherical approximation
+// with a nil error, UseExtendedVincenty retries with a relaxed budget before
+// falling back to ErrDidNotConverge, and FallBackBisect retries with
+// InverseBisect.
+func (g Geodesic)Inverse(point1, point2 LatLng) (Result, error) {
+	// short-circuit coincident points
+	if point1.Latitude == point2.Latitude && point1.Longitude == point2.Longitude {
+		return Result{Distance: Distance(0.0), Converged: true}, nil
+	}
+
+	res, iterations, converged := g.inverseVincenty(point1, point2, g.MaxIterations, g.ConvergenceThreshold)
+	if converged {
+		res.Iterations = iterations
+		res.Converged = true
+		return res, nil
+	}
+
+	switch g.NearAntipodalStrategy {
+	case FallBackToGreatCircle:
+		res = g.inverseGreatCircle(point1, point2)
+		res.Iterations = iterations
+		return res, nil
+	case FallBackBisect:
+		return g.InverseBisect(point1, point2), nil
+	case UseExtendedVincenty:
+		res, iterations, converged = g.inverseVincenty(point1, point2, g.MaxIterations * 10, g.ConvergenceThreshold * 10)
+		if converged {
+			res.Iterations = iterations
+			res.Converged = true
+			return res, nil
+		}
+		return Result{Distance: Distance(-1.0), Iterations: iterations}, ErrDidNotConverge
+	default:
+		return Result{Distance: Distance(-1.0), Iterations: iterations}, ErrDidNotConverge
+	}
+}
+
+// inverseVincenty runs Vincenty's inverse iteration to convergence, or until
+// maxIterations is reached, and reports how many iterations it used and
+// whether it converged.
+func (g Geodesic)inverseVincenty(point1, point2 LatLng, maxIterations int, convergenceThreshold float64) (Result, int, bool) {
+	a := g.Ellipsoid.A
+	f := g.Ellipsoid.F
+	b := g.Ellipsoid.b()
+
+	U1 := math.Atan((1.0 - f) * math.Tan(radians(point1.Latitude)))
+	U2 := math.Atan((1.0 - f) * math.Tan(radians(point2.Latitude)))
+	L := radians(point2.Longitude - point1.Longitude)
+	Lambda := L
+
+	sinU1 := math.Sin(U1)
+	cosU1 := math.Cos(U1)
+	sinU2 := math.Sin(U2)
+	cosU2 := math.Cos(U2)
+
+	for i := 0; i < maxIterations; i++ {
+		sinLambda := math.Sin(Lambda)
+		cosLambda := math.Cos(Lambda)
+		sinSigma := math.Sqrt(square(cosU2 * sinLambda) + square(cosU1 * sinU2 - sinU1 * cosU2 * cosLambda))
+		if sinSigma == 0.0 {
+			return Result{Distance: Distance(0.0)}, i + 1, true  // coincident points
+		}
+		cosSigma := sinU1 * sinU2 + cosU1 * cosU2 * cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha := 1.0 - square(sinAlpha)
+		cos2SigmaM := 0.0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2.0 * sinU1 * sinU2 / cosSqAlpha
+		}
+		C := f / 16.0 * cosSqAlpha * (4.0 + f * (4.0 - 3.0 * cosSqAlpha))
+		LambdaPrev := Lambda
+		Lambda = L + (1.0 - C) * f * sinAlpha * (sigma + C * sinSigma * (cos2SigmaM + C * cosSigma * (-1.0 + 2.0 * square(cos2SigmaM))))
+		if math.Abs(Lambda - LambdaPrev) < convergenceThreshold {
+			// successful convergence
+			uSq := cosSqAlpha * (square(a) - square(b)) / square(b)
+			A := 1.0 + uSq / 16384.0 * (4096.0 + uSq * (-768.0 + uSq * (320.0 - 175.0 * uSq)))
+			B := uSq / 1024.0 * (256.0 + uSq * (-128.0 + uSq * (74.0 - 47.0 * uSq)))
+			deltaSigma := B * sinSigma * (cos2SigmaM + B / 4.0 * (cosSigma * (-1.0 + 2.0 * square(cos2SigmaM)) - B / 6.0 * cos2SigmaM * (-3.0 + 4.0 * square(sinSigma)) * (-3.0 + 4.0 * square(cos2SigmaM))))
+			s := b * A * (sigma - deltaSigma)
+			s = math.Round(s * 1000)/1000
+
+			alpha1 := math.Atan2(cosU2 * sinLambda, cosU1 * sinU2 - sinU1 * cosU2 * cosLambda)
+			alpha2 := math.Atan2(cosU1 * sinLambda, -sinU1 * cosU2 + cosU1 * sinU2 * cosLambda)
+
+			return Result{
+				Distance: Distance(s),
+				InitialBearing: Bearing(alpha1 * 180.0 / math.Pi),
+				FinalBearing: Bearing(alpha2 * 180.0 / math.Pi),
+			}, i + 1, true
+		}
+	}
+	return Result{Distance: Distance(-1.0)}, maxIterations, false
+}
+
+// inverseGreatCircle approximates the distance and bearings between two
+// points using the haversine formula on a sphere of radius equal to g's
+// ellipsoid's semi-major axis. It is used as a NearAntipodalStrategy
+// fallback, since the great-circle approximation always converges.
+func (g Geodesic)inverseGreatCircle(point1, point2 LatLng) Result {
+	phi1 := radians(point1.Latitude)
+	phi2 := radians(point2.Latitude)
+	deltaPhi := radians(point2.Latitude - point1.Latitude)
+	deltaLambda := radians(point2.Longitude - point1.Longitude)
+
+	sinHalfPhi := math.Sin(deltaPhi / 2.0)
+	sinHalfLambda := math.Sin(deltaLambda / 2.0)
+	h := square(sinHalfPhi) + math.Cos(phi1) * math.Cos(phi2) * square(sinHalfLambda)
+	if h > 1.0 {
+		h = 1.0 // rounding can push exactly antipodal points just past 1
+	}
+	c := 2.0 * math.Atan2(math.Sqrt(h), math.Sqrt(1.0 - h))
+	s := g.Ellipsoid.A * c
+
+	y := math.Sin(deltaLambda) * math.Cos(phi2)
+	x := math.Cos(phi1) * math.Sin(phi2) - math.Sin(phi1) * math.Cos(phi2) * math.Cos(deltaLambda)
+	alpha1 := math.Atan2(y, x)
+
+	yFinal := math.Sin(-deltaLambda) * math.Cos(phi1)
+	xFinal := math.Cos(phi2) * math.Sin(phi1) - math.Sin(phi2) * math.Cos(phi1) * math.Cos(-deltaLambda)
+	alpha2 := math.Atan2(yFinal, xFinal) + math.Pi
+
+	return Result{
+		Distance: Distance(math.Round(s * 1000)/1000),
+		InitialBearing: Bearing(alpha1 * 180.0 / math.Pi),
+		FinalBearing: Bearing(alpha2 * 180.0 / math.Pi),
+	}
+}
+
+// Direct calculates the destination point reached by travelling along a
+// geodesic on g's ellipsoid from start, on the given initial bearing (in
+// degrees, clockwise from true north), for the given distance, using
+// Vincenty's formula (direct method). It returns the destination point and
+// the final bearing (in degrees) at that point.
+func (g Geodesic)Direct(start LatLng, bearingDeg float64, dist Distance) (LatLng, float64) {
+	a := g.Ellipsoid.A
+	f := g.Ellipsoid.F
+	b := g.Ellipsoid.b()
+
+	alpha1 := radians(bearingDeg)
+	s := float64(dist)
+
+	U1 := math.Atan((1.0 - f) * math.Tan(radians(start.Latitude)))
+	sigma1 := math.Atan2(math.Tan(U1), math.Cos(alpha1))
+	sinAlpha := math.Cos(U1) * math.Sin(alpha1)
+	cosSqAlpha := 1.0 - square(sinAlpha)
+	uSq := cosSqAlpha * (square(a) - square(b)) / square(b)
+	A := 1.0 + uSq / 16384.0 * (4096.0 + uSq * (-768.0 + uSq * (320.0 - 175.0 * uSq)))
+	B := uSq / 1024.0 * (256.0 + uSq * (-128.0 + uSq * (74.0 - 47.0 * uSq)))
+
+	sinU1 := math.Sin(U1)
+	cosU1 := math.Cos(U1)
+
+	sigma := s / (b * A)
+	cos2SigmaM := 0.0
+	sinSigma := 0.0
+	cosSigma := 0.0
+
+	for i := 0; i < g.MaxIterations; i++ {
+		cos2SigmaM = math.Cos(2.0 * sigma1 + sigma)
+		sinSigma = math.Sin(sigma)
+		cosSigma = math.Cos(sigma)
+		deltaSigma := B * sinSigma * (cos2SigmaM + B / 4.0 * (cosSigma * (-1.0 + 2.0 * square(cos2SigmaM)) - B / 6.0 * cos2SigmaM * (-3.0 + 4.0 * square(sinSigma)) * (-3.0 + 4.0 * square(cos2SigmaM))))
+		sigmaPrev := sigma
+		sigma = s / (b * A) + deltaSigma
+		if math.Abs(sigma - sigmaPrev) < g.ConvergenceThreshold {
+			break
+		}
+	}
+
+	phi2 := math.Atan2(sinU1 * cosSigma + cosU1 * sinSigma * math.Cos(alpha1),
+		(1.0 - f) * math.Sqrt(square(sinAlpha) + square(sinU1 * sinSigma - cosU1 * cosSigma * math.Cos(alpha1))))
+	lambda := math.Atan2(sinSigma * math.Sin(alpha1), cosU1 * cosSigma - sinU1 * sinSigma * math.Cos(alpha1))
+	C := f / 16.0 * cosSqAlpha * (4.0 + f * (4.0 - 3.0 * cosSqAlpha))
+	L := lambda - (1.0 - C) * f * sinAlpha * (sigma + C * sinSigma * (cos2SigmaM + C * cosSigma * (-1.0 + 2.0 * square(cos2SigmaM))))
+	alpha2 := math.Atan2(sinAlpha, -sinU1 * sinSigma + cosU1 * cosSigma * math.Cos(alpha1))
+
+	destination := LatLng{
+		Latitude: phi2 * 180.0 / math.Pi,
+		Longitude: start.Longitude + L * 180.0 / math.Pi,
+	}
+
+	return destination, Bearing(alpha2 * 180.0 / math.Pi).Degrees()
+}
@@ -0,0 +1,158 @@
+package vincenty
+
+/**
+ * Copyright (c) 2020, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+)
+
+// Midpoint returns the point halfway along the geodesic between point1 and
+// point2, using DefaultGeodesic. If the distance between them cannot be
+// computed (see Geodesic.Inverse), it returns point1.
+func Midpoint(point1, point2 LatLng) LatLng {
+	return DefaultGeodesic.Midpoint(point1, point2)
+}
+
+// Midpoint returns the point halfway along the geodesic between point1 and
+// point2 on g's ellipsoid. If the distance between them cannot be computed
+// (see Geodesic.Inverse), it returns point1.
+func (g Geodesic)Midpoint(point1, point2 LatLng) LatLng {
+	return g.Intermediate(point1, point2, 0.5)
+}
+
+// Intermediate returns the point a given fraction of the way along the
+// geodesic from point1 to point2, using DefaultGeodesic. fraction 0 returns
+// point1 and fraction 1 returns point2; values outside [0, 1] extrapolate
+// beyond point2 (or behind point1). If the distance between point1 and
+// point2 cannot be computed (see Geodesic.Inverse), it returns point1.
+func Intermediate(point1, point2 LatLng, fraction float64) LatLng {
+	return DefaultGeodesic.Intermediate(point1, point2, fraction)
+}
+
+// Intermediate returns the point a given fraction of the way along the
+// geodesic from point1 to point2 on g's ellipsoid. fraction 0 returns point1
+// and fraction 1 returns point2; values outside [0, 1] extrapolate beyond
+// point2 (or behind point1). If the distance between point1 and point2 cannot
+// be computed (see Geodesic.Inverse), it returns point1.
+func (g Geodesic)Intermediate(point1, point2 LatLng, fraction float64) LatLng {
+	if point1.Latitude == point2.Latitude && point1.Longitude == point2.Longitude {
+		return point1
+	}
+	if fraction == 0.0 {
+		return point1
+	}
+
+	res, err := g.Inverse(point1, point2)
+	if err != nil {
+		return point1
+	}
+	if fraction == 1.0 {
+		return point2
+	}
+
+	destination, _ := g.Direct(point1, res.InitialBearing.Degrees(), Distance(float64(res.Distance)*fraction))
+	return destination
+}
+
+// IntermediatePoints returns n points evenly spaced along the geodesic from
+// point1 to point2, using DefaultGeodesic, including point1 and point2
+// themselves as the first and last elements. It returns nil if n <= 0, or if
+// the distance between point1 and point2 cannot be computed (see
+// Geodesic.Inverse).
+func IntermediatePoints(point1, point2 LatLng, n int) []LatLng {
+	return DefaultGeodesic.IntermediatePoints(point1, point2, n)
+}
+
+// IntermediatePoints returns n points evenly spaced along the geodesic from
+// point1 to point2 on g's ellipsoid, including point1 and point2 themselves
+// as the first and last elements. It returns nil if n <= 0, or if the
+// distance between point1 and point2 cannot be computed (see Geodesic.Inverse).
+func (g Geodesic)IntermediatePoints(point1, point2 LatLng, n int) []LatLng {
+	if n <= 0 {
+		return nil
+	}
+	if n == 1 {
+		return []LatLng{point1}
+	}
+
+	if point1.Latitude == point2.Latitude && point1.Longitude == point2.Longitude {
+		points := make([]LatLng, n)
+		for i := range points {
+			points[i] = point1
+		}
+		return points
+	}
+
+	res, err := g.Inverse(point1, point2)
+	if err != nil {
+		return nil
+	}
+
+	points := make([]LatLng, n)
+	for i := 0; i < n; i++ {
+		fraction := float64(i) / float64(n-1)
+		points[i], _ = g.Direct(point1, res.InitialBearing.Degrees(), Distance(float64(res.Distance)*fraction))
+	}
+	points[0] = point1
+	points[n-1] = point2
+	return points
+}
+
+// PolygonArea returns the approximate geodesic area, in square metres, of the
+// polygon described by points on DefaultGeodesic's ellipsoid. The polygon is
+// implicitly closed (the last point connects back to the first) and may be
+// specified in either winding order; the result is always positive.
+func PolygonArea(points []LatLng) float64 {
+	return DefaultGeodesic.PolygonArea(points)
+}
+
+// PolygonArea returns the approximate geodesic area, in square metres, of the
+// polygon described by points on g's ellipsoid. The polygon is implicitly
+// closed (the last point connects back to the first) and may be specified in
+// either winding order; the result is always positive.
+//
+// It uses the spherical excess formula on the sphere of g's ellipsoid's mean
+// radius (2a+b)/3, which is accurate to a fraction of a percent for
+// WGS84-sized bodies -- an ellipsoidal correction on the scale of Karney's
+// exact area integral is not implemented. Edges are assumed to take the
+// shorter way round, so a polygon edge spanning more than 180 degrees of
+// longitude (as opposed to merely crossing the antimeridian) is treated as
+// going the other way.
+func (g Geodesic)PolygonArea(points []LatLng) float64 {
+	if len(points) < 3 {
+		return 0.0
+	}
+
+	meanRadius := (2.0*g.Ellipsoid.A + g.Ellipsoid.b()) / 3.0
+
+	var total float64
+	n := len(points)
+	for i := 0; i < n; i++ {
+		p1 := points[i]
+		p2 := points[(i+1)%n]
+		lat1 := radians(p1.Latitude)
+		lat2 := radians(p2.Latitude)
+		lon1 := radians(p1.Longitude)
+		lon2 := radians(p2.Longitude)
+		total += normalizeLongitudeDelta(lon2-lon1) * (2.0 + math.Sin(lat1) + math.Sin(lat2))
+	}
+
+	return math.Abs(total * square(meanRadius) / 2.0)
+}
+
+// normalizeLongitudeDelta wraps a longitude difference, in radians, into
+// (-pi, pi], so that an edge crossing the antimeridian contributes a small
+// delta in the correct direction instead of one close to +-2*pi.
+func normalizeLongitudeDelta(delta float64) float64 {
+	delta = math.Mod(delta, 2.0*math.Pi)
+	if delta > math.Pi {
+		delta -= 2.0 * math.Pi
+	} else if delta <= -math.Pi {
+		delta += 2.0 * math.Pi
+	}
+	return delta
+}
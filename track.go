@@ -0,0 +1,269 @@
+package vincenty
+
+/**
+ * Copyright (c) 2020, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// LatLngAlt represents a point on Earth defined by its Latitude, Longitude and
+// Altitude (in metres above the ellipsoid), for use with 3D track lengths.
+type LatLngAlt struct {
+	Latitude float64
+	Longitude float64
+	Altitude float64
+}
+
+// PathSegments returns the geodesic distance of each leg of the polyline
+// described by points, i.e. len(points)-1 distances. It returns nil if points
+// has fewer than two elements.
+func PathSegments(points []LatLng) []Distance {
+	if len(points) < 2 {
+		return nil
+	}
+
+	segments := make([]Distance, len(points)-1)
+	for i := 1; i < len(points); i++ {
+		segments[i-1] = Inverse(points[i-1], points[i])
+	}
+	return segments
+}
+
+// PathLength returns the total geodesic length of the polyline described by
+// points, using Inverse for each leg.
+func PathLength(points []LatLng) Distance {
+	var total Distance
+	for _, segment := range PathSegments(points) {
+		total += segment
+	}
+	return total
+}
+
+// CumulativeLength returns, for each point in points, the geodesic distance
+// travelled along the polyline from points[0] up to and including that point.
+// The first element is always 0. It returns nil if points has fewer than two
+// elements.
+func CumulativeLength(points []LatLng) []Distance {
+	if len(points) < 2 {
+		return nil
+	}
+
+	cumulative := make([]Distance, len(points))
+	for i := 1; i < len(points); i++ {
+		cumulative[i] = cumulative[i-1] + Inverse(points[i-1], points[i])
+	}
+	return cumulative
+}
+
+// PathLength3D returns the total length of the polyline described by points,
+// accounting for elevation change on each leg as sqrt(d^2 + dh^2), where d is
+// the geodesic distance between consecutive points and dh is the difference
+// in their Altitude.
+func PathLength3D(points []LatLngAlt) Distance {
+	if len(points) < 2 {
+		return Distance(0.0)
+	}
+
+	plain := make([]LatLng, len(points))
+	for i, p := range points {
+		plain[i] = LatLng{Latitude: p.Latitude, Longitude: p.Longitude}
+	}
+
+	var total float64
+	segments := PathSegments(plain)
+	for i, segment := range segments {
+		dh := points[i+1].Altitude - points[i].Altitude
+		total += math.Sqrt(square(segment.Metres()) + square(dh))
+	}
+	return Distance(total)
+}
+
+// gpx mirrors the handful of GPX XML elements needed to read a track's
+// points; it ignores everything else in the document.
+type gpx struct {
+	XMLName xml.Name `xml:"gpx"`
+	Tracks []gpxTrack `xml:"trk"`
+}
+
+type gpxTrack struct {
+	Segments []gpxTrackSegment `xml:"trkseg"`
+}
+
+type gpxTrackSegment struct {
+	Points []gpxTrackPoint `xml:"trkpt"`
+}
+
+type gpxTrackPoint struct {
+	Latitude float64 `xml:"lat,attr"`
+	Longitude float64 `xml:"lon,attr"`
+	Elevation float64 `xml:"ele"`
+}
+
+// ReadGPXTrack reads every <trkpt> in a GPX document from r, across all
+// <trk>/<trkseg> elements, as a single flattened polyline in document order.
+func ReadGPXTrack(r io.Reader) ([]LatLngAlt, error) {
+	var doc gpx
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var points []LatLngAlt
+	for _, track := range doc.Tracks {
+		for _, segment := range track.Segments {
+			for _, point := range segment.Points {
+				points = append(points, LatLngAlt{
+					Latitude: point.Latitude,
+					Longitude: point.Longitude,
+					Altitude: point.Elevation,
+				})
+			}
+		}
+	}
+	return points, nil
+}
+
+// PathLengthGPX reads a GPX document from r and returns the total 3D length
+// of its track, using the <ele> elevation of each <trkpt> where present.
+func PathLengthGPX(r io.Reader) (Distance, error) {
+	points, err := ReadGPXTrack(r)
+	if err != nil {
+		return Distance(0.0), err
+	}
+	return PathLength3D(points), nil
+}
+
+// geoJSONGeometry mirrors the handful of GeoJSON fields needed to read
+// LineString and MultiLineString geometries; it ignores every other field.
+// It does not unwrap a Feature or FeatureCollection: the document must be a
+// bare geometry object.
+type geoJSONGeometry struct {
+	Type string `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// ReadGeoJSONLineStrings reads a GeoJSON LineString or MultiLineString
+// geometry from r and returns its line(s) as polylines, one per
+// LineString. Coordinates are read as [longitude, latitude] or
+// [longitude, latitude, altitude], per the GeoJSON spec.
+func ReadGeoJSONLineStrings(r io.Reader) ([][]LatLngAlt, error) {
+	var geometry geoJSONGeometry
+	if err := json.NewDecoder(r).Decode(&geometry); err != nil {
+		return nil, err
+	}
+
+	switch geometry.Type {
+	case "LineString":
+		var coordinates [][]float64
+		if err := json.Unmarshal(geometry.Coordinates, &coordinates); err != nil {
+			return nil, err
+		}
+		line, err := geoJSONCoordinatesToLine(coordinates)
+		if err != nil {
+			return nil, err
+		}
+		return [][]LatLngAlt{line}, nil
+	case "MultiLineString":
+		var lines [][][]float64
+		if err := json.Unmarshal(geometry.Coordinates, &lines); err != nil {
+			return nil, err
+		}
+		result := make([][]LatLngAlt, len(lines))
+		for i, coordinates := range lines {
+			line, err := geoJSONCoordinatesToLine(coordinates)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = line
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("vincenty: unsupported GeoJSON geometry type %q", geometry.Type)
+	}
+}
+
+// geoJSONCoordinatesToLine converts a GeoJSON coordinate array, each element
+// [lon, lat] or [lon, lat, alt], to a polyline of LatLngAlt.
+func geoJSONCoordinatesToLine(coordinates [][]float64) ([]LatLngAlt, error) {
+	line := make([]LatLngAlt, len(coordinates))
+	for i, c := range coordinates {
+		if len(c) < 2 {
+			return nil, fmt.Errorf("vincenty: GeoJSON coordinate %v has fewer than 2 elements", c)
+		}
+		point := LatLngAlt{Longitude: c[0], Latitude: c[1]}
+		if len(c) > 2 {
+			point.Altitude = c[2]
+		}
+		line[i] = point
+	}
+	return line, nil
+}
+
+// PathLengthGeoJSON reads a GeoJSON LineString or MultiLineString geometry
+// from r and returns the total 3D length across all of its lines.
+func PathLengthGeoJSON(r io.Reader) (Distance, error) {
+	lines, err := ReadGeoJSONLineStrings(r)
+	if err != nil {
+		return Distance(0.0), err
+	}
+
+	var total Distance
+	for _, line := range lines {
+		total += PathLength3D(line)
+	}
+	return total, nil
+}
+
+// ReadCSVTrack reads a polyline from r as CSV rows of "latitude,longitude" or
+// "latitude,longitude,altitude". It returns an error if any row has fewer
+// than 2 fields, or if a field fails to parse as a float.
+func ReadCSVTrack(r io.Reader) ([]LatLngAlt, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]LatLngAlt, len(rows))
+	for i, row := range rows {
+		if len(row) < 2 {
+			return nil, fmt.Errorf("vincenty: CSV row %v has fewer than 2 fields", row)
+		}
+		lat, err := strconv.ParseFloat(row[0], 64)
+		if err != nil {
+			return nil, err
+		}
+		lon, err := strconv.ParseFloat(row[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		point := LatLngAlt{Latitude: lat, Longitude: lon}
+		if len(row) > 2 {
+			alt, err := strconv.ParseFloat(row[2], 64)
+			if err != nil {
+				return nil, err
+			}
+			point.Altitude = alt
+		}
+		points[i] = point
+	}
+	return points, nil
+}
+
+// PathLengthCSV reads a polyline from r (see ReadCSVTrack) and returns its
+// total 3D length.
+func PathLengthCSV(r io.Reader) (Distance, error) {
+	points, err := ReadCSVTrack(r)
+	if err != nil {
+		return Distance(0.0), err
+	}
+	return PathLength3D(points), nil
+}
@@ -59,6 +59,50 @@ type LatLng struct {
 	Longitude float64
 }
 
+// Bearing is an azimuth, measured clockwise from true north, as returned in the
+// Result of InverseDetailed() and Geodesic.Inverse()
+type Bearing float64
+
+// compassPoints are the labels used by Bearing.Compass(), in 22.5 degree steps
+var compassPoints = [16]string{
+	"N", "NNE", "NE", "ENE",
+	"E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW",
+	"W", "WNW", "NW", "NNW",
+}
+
+// Degrees returns the Bearing b in degrees, normalised to the range [0, 360)
+func (b Bearing)Degrees() float64 {
+	d := math.Mod(float64(b), 360.0)
+	if d < 0 {
+		d += 360.0
+	}
+	return d
+}
+
+// Radians returns the Bearing b in radians
+func (b Bearing)Radians() float64 {
+	return radians(b.Degrees())
+}
+
+// Compass returns the Bearing b as a 16-point compass direction, e.g. "NNE"
+func (b Bearing)Compass() string {
+	idx := int(math.Round(b.Degrees()/22.5)) % 16
+	return compassPoints[idx]
+}
+
+// Result is the return type of InverseDetailed() and Geodesic.Inverse(), giving
+// the distance between the two points along with the initial and final bearings
+// of the geodesic connecting them. Iterations and Converged report how the
+// underlying solver got there, for diagnostics.
+type Result struct {
+	Distance Distance
+	InitialBearing Bearing
+	FinalBearing Bearing
+	Iterations int
+	Converged bool
+}
+
 func radians(degrees float64) float64 {
 	return degrees * math.Pi / 180.0
 }
@@ -68,59 +112,32 @@ func square(f float64) float64 {
 }
 
 // Inverse calculates the distance between two points on the surface of a spheroid
-// using Vincenty's formula (inverse method)
+// using Vincenty's formula (inverse method), via DefaultGeodesic. If the formula
+// fails to converge, it returns Distance(-1.0); use DefaultGeodesic.Inverse directly
+// to distinguish that from a genuine error, or to configure a NearAntipodalStrategy.
 func Inverse(point1, point2 LatLng) Distance {
-	// WGS 84
-	a := 6378137.0  // meters
-	f := 1.0 / 298.257223563
-	b := 6356752.314245  // meters; b = (1 - f)a
-
-	MaxIterations := 200
-	ConvergenceThreshold := 1e-12  // .000,000,000,001
-
-	// short-circuit coincident points
-	if point1.Latitude == point2.Latitude && point1.Longitude == point2.Longitude {
-		return Distance(0.0)
+	res, err := DefaultGeodesic.Inverse(point1, point2)
+	if err != nil {
+		return Distance(-1.0)
 	}
+	return res.Distance
+}
 
-	U1 := math.Atan((1.0 - f) * math.Tan(radians(point1.Latitude)))
-	U2 := math.Atan((1.0 - f) * math.Tan(radians(point2.Latitude)))
-	L := radians(point2.Longitude - point1.Longitude)
-	Lambda := L
-
-	sinU1 := math.Sin(U1)
-	cosU1 := math.Cos(U1)
-	sinU2 := math.Sin(U2)
-	cosU2 := math.Cos(U2)
-
-	for i := 0; i < MaxIterations; i++ {
-		sinLambda := math.Sin(Lambda)
-		cosLambda := math.Cos(Lambda)
-		sinSigma := math.Sqrt(square(cosU2 * sinLambda) + square(cosU1 * sinU2 - sinU1 * cosU2 * cosLambda))
-		if sinSigma == 0.0 {
-			return Distance(0.0)  // coincident points
-		}
-		cosSigma := sinU1 * sinU2 + cosU1 * cosU2 * cosLambda
-		sigma := math.Atan2(sinSigma, cosSigma)
-		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
-		cosSqAlpha := 1.0 - square(sinAlpha)
-		cos2SigmaM := 0.0
-		if cosSqAlpha != 0 {
-			cos2SigmaM = cosSigma - 2.0 * sinU1 * sinU2 / cosSqAlpha
-		}
-		C := f / 16.0 * cosSqAlpha * (4.0 + f * (4.0 - 3.0 * cosSqAlpha))
-		LambdaPrev := Lambda
-		Lambda = L + (1.0 - C) * f * sinAlpha * (sigma + C * sinSigma * (cos2SigmaM + C * cosSigma * (-1.0 + 2.0 * square(cos2SigmaM))))
-		if math.Abs(Lambda - LambdaPrev) < ConvergenceThreshold {
-			// successful convergence
-			uSq := cosSqAlpha * (square(a) - square(b)) / square(b)
-			A := 1.0 + uSq / 16384.0 * (4096.0 + uSq * (-768.0 + uSq * (320.0 - 175.0 * uSq)))
-			B := uSq / 1024.0 * (256.0 + uSq * (-128.0 + uSq * (74.0 - 47.0 * uSq)))
-			deltaSigma := B * sinSigma * (cos2SigmaM + B / 4.0 * (cosSigma * (-1.0 + 2.0 * square(cos2SigmaM)) - B / 6.0 * cos2SigmaM * (-3.0 + 4.0 * square(sinSigma)) * (-3.0 + 4.0 * square(cos2SigmaM))))
-			s := b * A * (sigma - deltaSigma)
-			s = math.Round(s * 1000)/1000
-			return Distance(s)
-		}
-	}
-	return Distance(-1.0)
+// InverseDetailed calculates the distance between two points on the surface of a
+// spheroid using Vincenty's formula (inverse method), along with the initial and
+// final bearings of the geodesic connecting them, via DefaultGeodesic. If the
+// formula fails to converge, the returned Distance is -1.0 and the bearings are
+// zero-valued; use DefaultGeodesic.Inverse directly to get the error instead.
+func InverseDetailed(point1, point2 LatLng) Result {
+	res, _ := DefaultGeodesic.Inverse(point1, point2)
+	return res
+}
+
+// Direct calculates the destination point reached by travelling along a geodesic
+// from start, on the given initial bearing (in degrees, clockwise from true north),
+// for the given distance, using Vincenty's formula (direct method) via
+// DefaultGeodesic. It returns the destination point and the final bearing (in
+// degrees) at that point.
+func Direct(start LatLng, bearingDeg float64, dist Distance) (LatLng, float64) {
+	return DefaultGeodesic.Direct(start, bearingDeg, dist)
 }
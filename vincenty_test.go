@@ -11,7 +11,9 @@ import (
 	"os"
 	"io"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 	"time"
 	"encoding/csv"
 )
@@ -63,6 +65,63 @@ func TestFailureToConverge(t *testing.T) {
 	verifyInverse(point1, point2, expectedDist, t)
 }
 
+func TestInverseBisectEquatorialAntipodal(t *testing.T) {
+	point1 := LatLng{Latitude: 0.0, Longitude: 0.0}
+	point2 := LatLng{Latitude: 0.0, Longitude: 180.0}
+
+	res := InverseBisect(point1, point2)
+	if !res.Converged {
+		t.Fatalf("InverseBisect() did not converge for %v -> %v", point1, point2)
+	}
+	if res.Distance.Metres() < 1.9e7 || res.Distance.Metres() > 2.01e7 {
+		t.Errorf("InverseBisect() returned %v -- expected roughly half the Earth's circumference", res.Distance.Metres())
+	}
+}
+
+func TestInverseBisectNearAntipodal(t *testing.T) {
+	point1 := LatLng{Latitude: 0.0, Longitude: 0.0}
+	point2 := LatLng{Latitude: 0.0, Longitude: 179.9}
+
+	res := InverseBisect(point1, point2)
+	if !res.Converged {
+		t.Fatalf("InverseBisect() did not converge for %v -> %v", point1, point2)
+	}
+	if res.Distance.Metres() < 1.9e7 {
+		t.Errorf("InverseBisect() returned %v -- too short for a near-antipodal pair", res.Distance.Metres())
+	}
+}
+
+func TestInverseBisectMidLatitudeAntipodal(t *testing.T) {
+	pairs := []struct{ point1, point2 LatLng }{
+		{LatLng{Latitude: 10.0, Longitude: 0.0}, LatLng{Latitude: -10.0, Longitude: 180.0}},
+		{LatLng{Latitude: 45.0, Longitude: 0.0}, LatLng{Latitude: -45.0, Longitude: 180.0}},
+	}
+
+	for _, pair := range pairs {
+		res := InverseBisect(pair.point1, pair.point2)
+		if !res.Converged {
+			t.Errorf("InverseBisect() did not converge for %v -> %v", pair.point1, pair.point2)
+			continue
+		}
+		if res.Distance.Metres() < 1.9e7 || res.Distance.Metres() > 2.01e7 {
+			t.Errorf("InverseBisect() returned %v for %v -> %v -- expected roughly half the Earth's circumference", res.Distance.Metres(), pair.point1, pair.point2)
+		}
+		if math.IsNaN(float64(res.InitialBearing)) || math.IsNaN(float64(res.FinalBearing)) {
+			t.Errorf("InverseBisect() returned NaN bearing for %v -> %v", pair.point1, pair.point2)
+		}
+	}
+}
+
+func TestInverseBisectAgreesWithVincenty(t *testing.T) {
+	point1 := LatLng{Latitude: 0.0, Longitude: 0.0}
+	point2 := LatLng{Latitude: 0.0, Longitude: 1.0}
+
+	res := InverseBisect(point1, point2)
+	if res.Distance.Metres() != 111319.491 {
+		t.Errorf("InverseBisect() returned %v -- expected 111319.491 (agreeing with Inverse())", res.Distance.Metres())
+	}
+}
+
 func TestBostonNewYork(t *testing.T) {
 	Boston := LatLng{Latitude: 42.3541165, Longitude: -71.0693514}
         NewYork := LatLng{Latitude: 40.7791472, Longitude: -73.9680804}
@@ -71,6 +130,341 @@ func TestBostonNewYork(t *testing.T) {
 	verifyInverse(Boston, NewYork, expectedDist, t)
 }
 
+func TestRoundTrip(t *testing.T) {
+	Boston := LatLng{Latitude: 42.3541165, Longitude: -71.0693514}
+	NewYork := LatLng{Latitude: 40.7791472, Longitude: -73.9680804}
+
+	res := InverseDetailed(Boston, NewYork)
+	destination, finalBearing := Direct(Boston, res.InitialBearing.Degrees(), res.Distance)
+
+	if math.Abs(destination.Latitude-NewYork.Latitude) > 1e-6 || math.Abs(destination.Longitude-NewYork.Longitude) > 1e-6 {
+		t.Errorf("Direct() returned %v -- expected to land back on %v", destination, NewYork)
+	}
+	if math.Abs(finalBearing-res.FinalBearing.Degrees()) > 1e-6 {
+		t.Errorf("Direct() returned final bearing %v -- expected %v", finalBearing, res.FinalBearing.Degrees())
+	}
+}
+
+func TestKnownBearingDueEast(t *testing.T) {
+	point1 := LatLng{Latitude: 0.0, Longitude: 0.0}
+	point2 := LatLng{Latitude: 0.0, Longitude: 10.0}
+
+	res := InverseDetailed(point1, point2)
+	if math.Abs(res.InitialBearing.Degrees()-90.0) > 1e-6 {
+		t.Errorf("InverseDetailed() returned initial bearing %v -- expected due east (90)", res.InitialBearing.Degrees())
+	}
+	if res.InitialBearing.Compass() != "E" {
+		t.Errorf("Bearing.Compass() returned %q -- expected \"E\"", res.InitialBearing.Compass())
+	}
+}
+
+func TestKnownBearingDueNorth(t *testing.T) {
+	point1 := LatLng{Latitude: 0.0, Longitude: 0.0}
+	point2 := LatLng{Latitude: 10.0, Longitude: 0.0}
+
+	res := InverseDetailed(point1, point2)
+	if math.Abs(res.InitialBearing.Degrees()) > 1e-6 {
+		t.Errorf("InverseDetailed() returned initial bearing %v -- expected due north (0)", res.InitialBearing.Degrees())
+	}
+	if res.InitialBearing.Compass() != "N" {
+		t.Errorf("Bearing.Compass() returned %q -- expected \"N\"", res.InitialBearing.Compass())
+	}
+}
+
+func TestGeodesicReturnsErrDidNotConverge(t *testing.T) {
+	g := NewGeodesic(WGS84)
+	point1 := LatLng{Latitude: 0.0, Longitude: 0.0}
+	point2 := LatLng{Latitude: 0.5, Longitude: 179.7}
+
+	res, err := g.Inverse(point1, point2)
+	if err != ErrDidNotConverge {
+		t.Errorf("Inverse() returned error %v -- expected ErrDidNotConverge", err)
+	}
+	if res.Converged {
+		t.Errorf("Inverse() returned Converged=true alongside ErrDidNotConverge")
+	}
+}
+
+func TestGeodesicFallBackToGreatCircle(t *testing.T) {
+	g := NewGeodesic(WGS84)
+	g.NearAntipodalStrategy = FallBackToGreatCircle
+	point1 := LatLng{Latitude: 0.0, Longitude: 0.0}
+	point2 := LatLng{Latitude: 0.5, Longitude: 179.7}
+
+	res, err := g.Inverse(point1, point2)
+	if err != nil {
+		t.Fatalf("Inverse() returned error %v -- expected a great-circle approximation", err)
+	}
+	if res.Distance.Metres() < 1.9e7 || res.Distance.Metres() > 2.01e7 {
+		t.Errorf("Inverse() returned %v -- expected roughly half the Earth's circumference", res.Distance.Metres())
+	}
+}
+
+func TestGeodesicCustomEllipsoid(t *testing.T) {
+	g := NewGeodesic(Airy1830)
+	point1 := LatLng{Latitude: 0.0, Longitude: 0.0}
+	point2 := LatLng{Latitude: 0.0, Longitude: 1.0}
+
+	res, err := g.Inverse(point1, point2)
+	if err != nil {
+		t.Fatalf("Inverse() returned error %v", err)
+	}
+
+	expectedDist := radians(1.0) * Airy1830.A
+	if math.Abs(res.Distance.Metres()-expectedDist) > 0.001 {
+		t.Errorf("Inverse() on Airy1830 returned %v -- expected %v (a degree of longitude on the equator)", res.Distance.Metres(), expectedDist)
+	}
+	if res.Distance.Metres() == Inverse(point1, point2).Metres() {
+		t.Errorf("Inverse() on Airy1830 matched WGS84's Inverse() -- expected the ellipsoids to disagree")
+	}
+}
+
+func TestInverseBatch(t *testing.T) {
+	Boston := LatLng{Latitude: 42.3541165, Longitude: -71.0693514}
+	NewYork := LatLng{Latitude: 40.7791472, Longitude: -73.9680804}
+	pairs := []PointPair{
+		{Point1: LatLng{Latitude: 0.0, Longitude: 0.0}, Point2: LatLng{Latitude: 0.0, Longitude: 1.0}},
+		{Point1: Boston, Point2: NewYork},
+	}
+
+	results := InverseBatch(pairs)
+	if len(results) != len(pairs) {
+		t.Fatalf("InverseBatch() returned %v results -- expected %v", len(results), len(pairs))
+	}
+	for i, pair := range pairs {
+		expected, _ := DefaultGeodesic.Inverse(pair.Point1, pair.Point2)
+		if results[i].Distance != expected.Distance {
+			t.Errorf("InverseBatch()[%v] returned %v -- expected %v (agreeing with Inverse())", i, results[i].Distance, expected.Distance)
+		}
+	}
+}
+
+func TestInverseMatrix(t *testing.T) {
+	points := []LatLng{
+		{Latitude: 0.0, Longitude: 0.0},
+		{Latitude: 0.0, Longitude: 1.0},
+		{Latitude: 1.0, Longitude: 0.0},
+	}
+
+	matrix := InverseMatrix(points)
+	for i := range points {
+		if matrix[i][i] != Distance(0.0) {
+			t.Errorf("InverseMatrix()[%v][%v] returned %v -- expected 0", i, i, matrix[i][i])
+		}
+		for j := range points {
+			expected, _ := DefaultGeodesic.Inverse(points[i], points[j])
+			if matrix[i][j] != expected.Distance {
+				t.Errorf("InverseMatrix()[%v][%v] returned %v -- expected %v (agreeing with Inverse())", i, j, matrix[i][j], expected.Distance)
+			}
+			if matrix[i][j] != matrix[j][i] {
+				t.Errorf("InverseMatrix() is not symmetric at [%v][%v]: %v != %v", i, j, matrix[i][j], matrix[j][i])
+			}
+		}
+	}
+}
+
+func TestInverseStream(t *testing.T) {
+	pairs := []PointPair{
+		{Point1: LatLng{Latitude: 0.0, Longitude: 0.0}, Point2: LatLng{Latitude: 0.0, Longitude: 1.0}},
+		{Point1: LatLng{Latitude: 0.0, Longitude: 0.0}, Point2: LatLng{Latitude: 1.0, Longitude: 0.0}},
+	}
+
+	in := make(chan PointPair, len(pairs))
+	out := make(chan Result, len(pairs))
+	for _, pair := range pairs {
+		in <- pair
+	}
+	close(in)
+
+	InverseStream(in, out)
+
+	var got []Distance
+	for res := range out {
+		got = append(got, res.Distance)
+	}
+	if len(got) != len(pairs) {
+		t.Fatalf("InverseStream() produced %v results -- expected %v", len(got), len(pairs))
+	}
+
+	want := map[Distance]bool{}
+	for _, pair := range pairs {
+		expected, _ := DefaultGeodesic.Inverse(pair.Point1, pair.Point2)
+		want[expected.Distance] = true
+	}
+	for _, d := range got {
+		if !want[d] {
+			t.Errorf("InverseStream() produced unexpected distance %v", d)
+		}
+	}
+}
+
+func TestReadGPXTrack(t *testing.T) {
+	doc := `<?xml version="1.0"?>
+<gpx><trk><trkseg>
+<trkpt lat="0.0" lon="0.0"><ele>10</ele></trkpt>
+<trkpt lat="0.0" lon="1.0"><ele>20</ele></trkpt>
+</trkseg></trk></gpx>`
+
+	points, err := ReadGPXTrack(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ReadGPXTrack() returned error %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("ReadGPXTrack() returned %v points -- expected 2", len(points))
+	}
+	if points[1].Longitude != 1.0 || points[1].Altitude != 20.0 {
+		t.Errorf("ReadGPXTrack() returned %v -- unexpected second point", points[1])
+	}
+
+	length, err := PathLengthGPX(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("PathLengthGPX() returned error %v", err)
+	}
+	if length.Metres() < 111319.0 {
+		t.Errorf("PathLengthGPX() returned %v -- expected at least a degree of longitude", length.Metres())
+	}
+}
+
+func TestReadGeoJSONLineStrings(t *testing.T) {
+	doc := `{"type":"LineString","coordinates":[[0.0,0.0],[1.0,0.0,20.0]]}`
+
+	lines, err := ReadGeoJSONLineStrings(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ReadGeoJSONLineStrings() returned error %v", err)
+	}
+	if len(lines) != 1 || len(lines[0]) != 2 {
+		t.Fatalf("ReadGeoJSONLineStrings() returned %v -- expected a single 2-point line", lines)
+	}
+	if lines[0][1].Latitude != 0.0 || lines[0][1].Longitude != 1.0 || lines[0][1].Altitude != 20.0 {
+		t.Errorf("ReadGeoJSONLineStrings() returned %v -- unexpected second point", lines[0][1])
+	}
+
+	length, err := PathLengthGeoJSON(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("PathLengthGeoJSON() returned error %v", err)
+	}
+	if length.Metres() < 111319.0 {
+		t.Errorf("PathLengthGeoJSON() returned %v -- expected at least a degree of longitude", length.Metres())
+	}
+}
+
+func TestReadGeoJSONLineStringsShortCoordinate(t *testing.T) {
+	doc := `{"type":"LineString","coordinates":[[0.0,0.0],[1.0]]}`
+
+	if _, err := ReadGeoJSONLineStrings(strings.NewReader(doc)); err == nil {
+		t.Fatalf("ReadGeoJSONLineStrings() did not return an error for a short coordinate")
+	}
+}
+
+func TestReadCSVTrack(t *testing.T) {
+	doc := "0.0,0.0,10\n0.0,1.0,20\n"
+
+	points, err := ReadCSVTrack(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ReadCSVTrack() returned error %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("ReadCSVTrack() returned %v points -- expected 2", len(points))
+	}
+	if points[1].Longitude != 1.0 || points[1].Altitude != 20.0 {
+		t.Errorf("ReadCSVTrack() returned %v -- unexpected second point", points[1])
+	}
+
+	length, err := PathLengthCSV(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("PathLengthCSV() returned error %v", err)
+	}
+	if length.Metres() < 111319.0 {
+		t.Errorf("PathLengthCSV() returned %v -- expected at least a degree of longitude", length.Metres())
+	}
+}
+
+func TestReadCSVTrackShortRow(t *testing.T) {
+	doc := "0.0,0.0\n0.0\n"
+
+	if _, err := ReadCSVTrack(strings.NewReader(doc)); err == nil {
+		t.Fatalf("ReadCSVTrack() did not return an error for a short row")
+	}
+}
+
+func TestMidpoint(t *testing.T) {
+	point1 := LatLng{Latitude: 0.0, Longitude: 0.0}
+	point2 := LatLng{Latitude: 0.0, Longitude: 10.0}
+
+	mid := Midpoint(point1, point2)
+	if math.Abs(mid.Latitude) > 1e-6 || math.Abs(mid.Longitude-5.0) > 1e-6 {
+		t.Errorf("Midpoint() returned %v -- expected (0, 5)", mid)
+	}
+}
+
+func TestIntermediate(t *testing.T) {
+	point1 := LatLng{Latitude: 0.0, Longitude: 0.0}
+	point2 := LatLng{Latitude: 0.0, Longitude: 10.0}
+
+	if got := Intermediate(point1, point2, 0.0); got != point1 {
+		t.Errorf("Intermediate(..., 0) returned %v -- expected point1 %v", got, point1)
+	}
+	quarter := Intermediate(point1, point2, 0.25)
+	if math.Abs(quarter.Longitude-2.5) > 1e-6 {
+		t.Errorf("Intermediate(..., 0.25) returned %v -- expected longitude 2.5", quarter)
+	}
+}
+
+func TestIntermediatePoints(t *testing.T) {
+	point1 := LatLng{Latitude: 0.0, Longitude: 0.0}
+	point2 := LatLng{Latitude: 0.0, Longitude: 10.0}
+
+	points := IntermediatePoints(point1, point2, 5)
+	if len(points) != 5 {
+		t.Fatalf("IntermediatePoints() returned %v points -- expected 5", len(points))
+	}
+	if points[0] != point1 {
+		t.Errorf("IntermediatePoints()[0] returned %v -- expected point1 %v", points[0], point1)
+	}
+	if math.Abs(points[4].Longitude-point2.Longitude) > 1e-6 {
+		t.Errorf("IntermediatePoints()[4] returned %v -- expected point2 %v", points[4], point2)
+	}
+	if math.Abs(points[2].Longitude-5.0) > 1e-6 {
+		t.Errorf("IntermediatePoints()[2] returned %v -- expected the midpoint (longitude 5)", points[2])
+	}
+}
+
+func TestPolygonArea(t *testing.T) {
+	square := []LatLng{
+		{Latitude: 0.0, Longitude: 0.0},
+		{Latitude: 0.0, Longitude: 1.0},
+		{Latitude: 1.0, Longitude: 1.0},
+		{Latitude: 1.0, Longitude: 0.0},
+	}
+
+	area := PolygonArea(square)
+	expected := 12363718034.235
+	if math.Abs(area-expected)/expected > 1e-6 {
+		t.Errorf("PolygonArea() returned %v -- expected %v", area, expected)
+	}
+}
+
+func TestPolygonAreaAcrossAntimeridian(t *testing.T) {
+	square := []LatLng{
+		{Latitude: 0.0, Longitude: 0.0},
+		{Latitude: 0.0, Longitude: 1.0},
+		{Latitude: 1.0, Longitude: 1.0},
+		{Latitude: 1.0, Longitude: 0.0},
+	}
+	acrossAntimeridian := []LatLng{
+		{Latitude: 0.0, Longitude: 179.5},
+		{Latitude: 0.0, Longitude: -179.5},
+		{Latitude: 1.0, Longitude: -179.5},
+		{Latitude: 1.0, Longitude: 179.5},
+	}
+
+	want := PolygonArea(square)
+	got := PolygonArea(acrossAntimeridian)
+	if math.Abs(got-want)/want > 1e-6 {
+		t.Errorf("PolygonArea() across the antimeridian returned %v -- expected %v (same size as an equivalent square elsewhere)", got, want)
+	}
+}
+
 func TestSpeed(t *testing.T) {
 	var testData [][]float64
 	testDataFile, err := os.Open("testdata.csv")